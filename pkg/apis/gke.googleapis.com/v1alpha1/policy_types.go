@@ -0,0 +1,65 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CertificateStatusRejected is set on a ManagedCertificate whose domains
+	// were refused by the cluster's domain policy, before any backend
+	// resource was created for it.
+	CertificateStatusRejected = "Rejected"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DomainPolicy is a cluster-scoped resource that restricts which domains may
+// appear in any ManagedCertificate's spec. A domain is admitted only if it
+// matches at least one Allow rule and no Deny rule; Deny always wins. If
+// Allow is empty, any domain that matches no Deny rule is admitted.
+type DomainPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DomainPolicySpec `json:"spec"`
+}
+
+// DomainPolicySpec is the spec for a DomainPolicy resource.
+type DomainPolicySpec struct {
+	// Allow lists the domain patterns a ManagedCertificate's domains must
+	// match at least one of. Patterns may be exact DNS names
+	// ("example.com"), a single leading wildcard label ("*.example.com"),
+	// or a DNS suffix (".example.com"). An empty Allow list places no such
+	// requirement. See pkg/policy for exact matching rules.
+	Allow []string `json:"allow,omitempty"`
+
+	// Deny lists domain patterns that are always rejected, even if a
+	// matching Allow rule also exists.
+	Deny []string `json:"deny,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DomainPolicyList is a list of DomainPolicy resources.
+type DomainPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []DomainPolicy `json:"items"`
+}
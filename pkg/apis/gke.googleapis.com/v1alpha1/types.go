@@ -0,0 +1,64 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 defines the ManagedCertificate custom resource.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ManagedCertificate represents a set of domains for which an SSL certificate
+// should be provisioned and kept up to date.
+type ManagedCertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ManagedCertificateSpec   `json:"spec"`
+	Status ManagedCertificateStatus `json:"status,omitempty"`
+}
+
+// ManagedCertificateSpec is the spec for a ManagedCertificate resource.
+type ManagedCertificateSpec struct {
+	Domains []string `json:"domains"`
+}
+
+// ManagedCertificateStatus is the status for a ManagedCertificate resource.
+type ManagedCertificateStatus struct {
+	CertificateName   string                           `json:"certificateName,omitempty"`
+	CertificateStatus string                           `json:"certificateStatus,omitempty"`
+	DomainStatus      []ManagedCertificateDomainStatus `json:"domainStatus,omitempty"`
+	ExpireTime        string                           `json:"expireTime,omitempty"`
+}
+
+// ManagedCertificateDomainStatus reports the provisioning status of a single
+// domain within a ManagedCertificate.
+type ManagedCertificateDomainStatus struct {
+	Domain string `json:"domain"`
+	Status string `json:"status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ManagedCertificateList is a list of ManagedCertificate resources.
+type ManagedCertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ManagedCertificate `json:"items"`
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apiv1 defines the pluggable certificate backend abstraction used
+// by sslcertificatemanager, along with a registry of named backend
+// constructors. It mirrors the CAS (certificate authority service)
+// abstraction layer from smallstep/certificates: a Type identifies a
+// backend implementation, Options carries its configuration, and New
+// looks up the right constructor by Type.
+package apiv1
+
+import (
+	"fmt"
+
+	compute "google.golang.org/api/compute/v0.beta"
+)
+
+// Type identifies a certificate backend implementation.
+type Type string
+
+const (
+	// ComputeSSL is the existing GCE Compute Beta SslCertificates backend.
+	ComputeSSL Type = "computeSSL"
+
+	// CertificateManager is Google's Certificate Manager service.
+	CertificateManager Type = "certificateManager"
+
+	// Soft is an in-memory backend used by tests.
+	Soft Type = "soft"
+
+	// DefaultType names the backend to use when no specific one is
+	// requested. ManagedCertificateSpec has no field that selects a backend,
+	// and this module defines no flag for it.
+	DefaultType = ComputeSSL
+)
+
+// Options carries the configuration needed to construct a Backend. Not every
+// field is meaningful to every backend implementation.
+type Options struct {
+	// Project is the GCP project that owns the backend's resources.
+	Project string
+
+	// Region is set for backends whose resources are regional, such as
+	// Certificate Manager certificates.
+	Region string
+}
+
+// Backend abstracts a certificate provisioning service that
+// sslcertificatemanager can drive to satisfy a ManagedCertificate.
+type Backend interface {
+	// Create creates a new managed certificate resource named name,
+	// covering domains.
+	Create(name string, domains []string) error
+
+	// Delete deletes the managed certificate resource named name.
+	Delete(name string) error
+
+	// Exists reports whether a managed certificate resource named name
+	// exists.
+	Exists(name string) (bool, error)
+
+	// Get fetches the managed certificate resource named name, in the
+	// Compute SslCertificate shape shared across backends.
+	Get(name string) (*compute.SslCertificate, error)
+
+	// Type reports which backend implementation this is.
+	Type() Type
+
+	// IsQuotaExceeded reports whether err represents this backend's
+	// provider-specific "too many certificates" quota error, so that
+	// callers can raise TooManyCertificates rather than a generic
+	// BackendError.
+	IsQuotaExceeded(err error) bool
+}
+
+// DescriptionSetter is implemented by backends that can persist a
+// free-text description alongside a certificate at creation time. The
+// certbackend/apiv1/compute, .../certificatemanager and .../soft backends
+// all implement it so that pkg/provenance can stamp a certificate's
+// creator onto the backend resource itself, not just as a
+// ManagedCertificate annotation.
+type DescriptionSetter interface {
+	CreateWithDescription(name string, domains []string, description string) error
+}
+
+// Constructor builds a Backend from Options.
+type Constructor func(Options) (Backend, error)
+
+// Registry maps a Type to the Constructor that builds it, mirroring
+// smallstep's cas/apiv1 registry.
+type Registry struct {
+	constructors map[Type]Constructor
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{constructors: make(map[Type]Constructor)}
+}
+
+// defaultRegistry is the Registry populated by backend implementations'
+// init() functions via Register.
+var defaultRegistry = NewRegistry()
+
+// Register associates typ with constructor in the default Registry. It is
+// meant to be called from the init() function of a backend implementation
+// package.
+func Register(typ Type, constructor Constructor) {
+	defaultRegistry.Register(typ, constructor)
+}
+
+// Register associates typ with constructor in r.
+func (r *Registry) Register(typ Type, constructor Constructor) {
+	r.constructors[typ] = constructor
+}
+
+// New builds a Backend of the given Type using the default Registry.
+func New(typ Type, options Options) (Backend, error) {
+	return defaultRegistry.New(typ, options)
+}
+
+// New builds a Backend of the given Type using r.
+func (r *Registry) New(typ Type, options Options) (Backend, error) {
+	constructor, ok := r.constructors[typ]
+	if !ok {
+		return nil, fmt.Errorf("certbackend: no backend registered for type %q", typ)
+	}
+	return constructor(options)
+}
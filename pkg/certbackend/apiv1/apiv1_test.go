@@ -0,0 +1,57 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiv1
+
+import (
+	"testing"
+)
+
+type fakeBackend struct {
+	Backend
+	options Options
+}
+
+func fakeConstructor(options Options) (Backend, error) {
+	return &fakeBackend{options: options}, nil
+}
+
+func TestRegistryNew(t *testing.T) {
+	r := NewRegistry()
+	r.Register(ComputeSSL, fakeConstructor)
+
+	options := Options{Project: "my-project"}
+	backend, err := r.New(ComputeSSL, options)
+	if err != nil {
+		t.Fatalf("New() error = %v, want nil", err)
+	}
+
+	got, ok := backend.(*fakeBackend)
+	if !ok {
+		t.Fatalf("New() returned %T, want *fakeBackend", backend)
+	}
+	if got.options != options {
+		t.Errorf("New() built backend with options %+v, want %+v", got.options, options)
+	}
+}
+
+func TestRegistryNewUnregisteredType(t *testing.T) {
+	r := NewRegistry()
+
+	if _, err := r.New(ComputeSSL, Options{}); err == nil {
+		t.Error("New() for an unregistered type succeeded, want error")
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certificatemanager registers the certbackend/apiv1 backend that
+// drives Google's Certificate Manager service, as an alternative to the
+// older Compute Beta SslCertificates API.
+package certificatemanager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	certificatemanager "google.golang.org/api/certificatemanager/v1"
+	compute "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/googleapi"
+
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/certbackend/apiv1"
+)
+
+func init() {
+	apiv1.Register(apiv1.CertificateManager, New)
+}
+
+// defaultRegion is used when options.Region is empty, as Certificate
+// Manager certificates are regional resources but "global" is the only
+// region that supports classic managed certificates today.
+const defaultRegion = "global"
+
+type backend struct {
+	service *certificatemanager.ProjectsLocationsCertificatesService
+	parent  string
+}
+
+// New constructs a certbackend/apiv1.Backend backed by Google's Certificate
+// Manager service for the project and region in options.
+func New(options apiv1.Options) (apiv1.Backend, error) {
+	client, err := certificatemanager.NewService(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	region := options.Region
+	if region == "" {
+		region = defaultRegion
+	}
+
+	return &backend{
+		service: certificatemanager.NewProjectsLocationsCertificatesService(client),
+		parent:  fmt.Sprintf("projects/%s/locations/%s", options.Project, region),
+	}, nil
+}
+
+func (b *backend) Create(name string, domains []string) error {
+	return b.CreateWithDescription(name, domains, "")
+}
+
+// CreateWithDescription implements apiv1.DescriptionSetter.
+func (b *backend) CreateWithDescription(name string, domains []string, description string) error {
+	_, err := b.service.Create(b.parent, &certificatemanager.Certificate{
+		Managed: &certificatemanager.ManagedCertificate{
+			Domains: domains,
+		},
+		Description: description,
+	}).CertificateId(name).Do()
+	return err
+}
+
+func (b *backend) Delete(name string) error {
+	_, err := b.service.Delete(b.fullName(name)).Do()
+	return err
+}
+
+func (b *backend) Exists(name string) (bool, error) {
+	if _, err := b.Get(name); err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (b *backend) Get(name string) (*compute.SslCertificate, error) {
+	cert, err := b.service.Get(b.fullName(name)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return toComputeSslCertificate(name, cert), nil
+}
+
+func (b *backend) Type() apiv1.Type {
+	return apiv1.CertificateManager
+}
+
+// IsQuotaExceeded reports whether err is Certificate Manager's
+// RESOURCE_EXHAUSTED quota error, which is surfaced with a different status
+// code and reason than the Compute Beta API's quotaExceeded error.
+func (b *backend) IsQuotaExceeded(err error) bool {
+	gerr, ok := err.(*googleapi.Error)
+	return ok && gerr.Code == http.StatusTooManyRequests
+}
+
+func (b *backend) fullName(name string) string {
+	return fmt.Sprintf("%s/certificates/%s", b.parent, name)
+}
+
+func isNotFound(err error) bool {
+	gerr, ok := err.(*googleapi.Error)
+	return ok && gerr.Code == http.StatusNotFound
+}
+
+func toComputeSslCertificate(name string, cert *certificatemanager.Certificate) *compute.SslCertificate {
+	var domains []string
+	if cert.Managed != nil {
+		domains = cert.Managed.Domains
+	}
+	return &compute.SslCertificate{
+		Name: name,
+		Managed: &compute.SslCertificateManagedSslCertificate{
+			Domains: domains,
+		},
+		Type:        "MANAGED",
+		Description: cert.Description,
+	}
+}
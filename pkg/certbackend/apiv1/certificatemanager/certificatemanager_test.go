@@ -0,0 +1,106 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificatemanager
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	certificatemanager "google.golang.org/api/certificatemanager/v1"
+	compute "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/googleapi"
+)
+
+func TestIsQuotaExceeded(t *testing.T) {
+	testCases := []struct {
+		desc string
+		err  error
+		want bool
+	}{
+		{"RESOURCE_EXHAUSTED maps to quota exceeded", &googleapi.Error{Code: http.StatusTooManyRequests}, true},
+		{"not found is not quota exceeded", &googleapi.Error{Code: http.StatusNotFound}, false},
+		{"the Compute API's quotaExceeded status does not apply here", &googleapi.Error{Code: http.StatusForbidden}, false},
+		{"a non-googleapi error is not quota exceeded", errors.New("boom"), false},
+	}
+
+	b := &backend{}
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			if got := b.IsQuotaExceeded(testCase.err); got != testCase.want {
+				t.Errorf("IsQuotaExceeded(%v) = %t, want %t", testCase.err, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestToComputeSslCertificate(t *testing.T) {
+	cert := &certificatemanager.Certificate{
+		Managed:     &certificatemanager.ManagedCertificate{Domains: []string{"example.com"}},
+		Description: "encoded-provenance",
+	}
+
+	want := &compute.SslCertificate{
+		Name: "cert-1",
+		Managed: &compute.SslCertificateManagedSslCertificate{
+			Domains: []string{"example.com"},
+		},
+		Type:        "MANAGED",
+		Description: "encoded-provenance",
+	}
+
+	if got := toComputeSslCertificate("cert-1", cert); !reflect.DeepEqual(got, want) {
+		t.Errorf("toComputeSslCertificate() = %#v, want %#v", got, want)
+	}
+}
+
+func TestToComputeSslCertificateWithoutManaged(t *testing.T) {
+	got := toComputeSslCertificate("cert-1", &certificatemanager.Certificate{})
+	if got.Managed == nil || got.Managed.Domains != nil {
+		t.Errorf("toComputeSslCertificate() for an unmanaged certificate = %#v, want a Managed field with no domains", got)
+	}
+}
+
+func TestFullName(t *testing.T) {
+	b := &backend{parent: "projects/my-project/locations/global"}
+
+	want := "projects/my-project/locations/global/certificates/cert-1"
+	if got := b.fullName("cert-1"); got != want {
+		t.Errorf("fullName() = %q, want %q", got, want)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	testCases := []struct {
+		desc string
+		err  error
+		want bool
+	}{
+		{"404 is not found", &googleapi.Error{Code: http.StatusNotFound}, true},
+		{"403 is not not-found", &googleapi.Error{Code: http.StatusForbidden}, false},
+		{"a non-googleapi error is not not-found", errors.New("boom"), false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			if got := isNotFound(testCase.err); got != testCase.want {
+				t.Errorf("isNotFound(%v) = %t, want %t", testCase.err, got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compute registers the certbackend/apiv1 backend that drives the
+// existing GCE Compute Beta SslCertificates API.
+package compute
+
+import (
+	"context"
+	"net/http"
+
+	compute "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/googleapi"
+
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/certbackend/apiv1"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/client/ssl"
+)
+
+func init() {
+	apiv1.Register(apiv1.ComputeSSL, New)
+}
+
+type backend struct {
+	ssl ssl.Ssl
+}
+
+// New constructs a certbackend/apiv1.Backend backed by the GCE Compute Beta
+// SslCertificates API for the project in options.
+func New(options apiv1.Options) (apiv1.Backend, error) {
+	client, err := compute.NewService(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &backend{ssl: ssl.New(compute.NewSslCertificatesService(client), options.Project)}, nil
+}
+
+func (b *backend) Create(name string, domains []string) error {
+	return b.ssl.Create(name, domains)
+}
+
+// CreateWithDescription implements apiv1.DescriptionSetter.
+func (b *backend) CreateWithDescription(name string, domains []string, description string) error {
+	return b.ssl.CreateWithDescription(name, domains, description)
+}
+
+func (b *backend) Delete(name string) error {
+	return b.ssl.Delete(name)
+}
+
+func (b *backend) Exists(name string) (bool, error) {
+	return b.ssl.Exists(name)
+}
+
+func (b *backend) Get(name string) (*compute.SslCertificate, error) {
+	return b.ssl.Get(name)
+}
+
+func (b *backend) Type() apiv1.Type {
+	return apiv1.ComputeSSL
+}
+
+func (b *backend) IsQuotaExceeded(err error) bool {
+	gerr, ok := err.(*googleapi.Error)
+	if !ok || gerr.Code != http.StatusForbidden {
+		return false
+	}
+	for _, item := range gerr.Errors {
+		if item.Reason == "quotaExceeded" {
+			return true
+		}
+	}
+	return false
+}
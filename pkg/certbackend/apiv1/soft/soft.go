@@ -0,0 +1,114 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package soft registers an in-memory certbackend/apiv1.Backend with no
+// external dependencies, for use in tests and local development.
+package soft
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	compute "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/googleapi"
+
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/certbackend/apiv1"
+)
+
+func init() {
+	apiv1.Register(apiv1.Soft, New)
+}
+
+type backend struct {
+	mu    sync.Mutex
+	certs map[string]*compute.SslCertificate
+}
+
+// New constructs an in-memory certbackend/apiv1.Backend. It ignores options,
+// as it has no external project or region to talk to.
+func New(options apiv1.Options) (apiv1.Backend, error) {
+	return &backend{certs: make(map[string]*compute.SslCertificate)}, nil
+}
+
+func (b *backend) Create(name string, domains []string) error {
+	return b.CreateWithDescription(name, domains, "")
+}
+
+// CreateWithDescription implements apiv1.DescriptionSetter.
+func (b *backend) CreateWithDescription(name string, domains []string, description string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.certs[name] = &compute.SslCertificate{
+		Name: name,
+		Managed: &compute.SslCertificateManagedSslCertificate{
+			Domains: domains,
+		},
+		Type:        "MANAGED",
+		Description: description,
+	}
+	return nil
+}
+
+func (b *backend) Delete(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.certs[name]; !exists {
+		return notFoundError(name)
+	}
+	delete(b.certs, name)
+	return nil
+}
+
+func (b *backend) Exists(name string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	_, exists := b.certs[name]
+	return exists, nil
+}
+
+func (b *backend) Get(name string) (*compute.SslCertificate, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cert, exists := b.certs[name]
+	if !exists {
+		return nil, notFoundError(name)
+	}
+	return cert, nil
+}
+
+// notFoundError reports the absence of a certificate named name as a
+// *googleapi.Error with a 404 status, so that sslcertificatemanager's
+// isNotFound recognizes it the same way it does the compute and
+// certificatemanager backends' not-found errors.
+func notFoundError(name string) error {
+	return &googleapi.Error{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("soft: certificate %q not found", name),
+	}
+}
+
+func (b *backend) Type() apiv1.Type {
+	return apiv1.Soft
+}
+
+func (b *backend) IsQuotaExceeded(err error) bool {
+	return false
+}
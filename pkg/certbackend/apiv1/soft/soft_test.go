@@ -0,0 +1,52 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package soft
+
+import (
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/certbackend/apiv1"
+)
+
+func TestDeleteMissingReturnsNotFound(t *testing.T) {
+	b, err := New(apiv1.Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v, want nil", err)
+	}
+
+	err = b.Delete("missing")
+	gerr, ok := err.(*googleapi.Error)
+	if !ok || gerr.Code != http.StatusNotFound {
+		t.Errorf("Delete() of a missing certificate returned %#v, want a *googleapi.Error with Code %d", err, http.StatusNotFound)
+	}
+}
+
+func TestGetMissingReturnsNotFound(t *testing.T) {
+	b, err := New(apiv1.Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v, want nil", err)
+	}
+
+	_, err = b.Get("missing")
+	gerr, ok := err.(*googleapi.Error)
+	if !ok || gerr.Code != http.StatusNotFound {
+		t.Errorf("Get() of a missing certificate returned %#v, want a *googleapi.Error with Code %d", err, http.StatusNotFound)
+	}
+}
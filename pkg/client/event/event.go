@@ -0,0 +1,86 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package event reports ManagedCertificate lifecycle events to the
+// Kubernetes event recorder.
+package event
+
+import (
+	"k8s.io/client-go/tools/record"
+
+	api "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/gke.googleapis.com/v1alpha1"
+)
+
+const (
+	backendError        = "BackendError"
+	create              = "Create"
+	deleteReason        = "Delete"
+	policyViolation     = "PolicyViolation"
+	tooManyCertificates = "TooManyCertificates"
+)
+
+// Event reports events associated with managing a ManagedCertificate's
+// backing SslCertificate.
+type Event interface {
+	// BackendError reports an error returned by the certificate backend that
+	// is not otherwise handled more specifically.
+	BackendError(mcrt api.ManagedCertificate, err error)
+
+	// Create reports that an SslCertificate resource named
+	// sslCertificateName has been created for mcrt.
+	Create(mcrt api.ManagedCertificate, sslCertificateName string)
+
+	// Delete reports that an SslCertificate resource named
+	// sslCertificateName has been deleted for mcrt.
+	Delete(mcrt api.ManagedCertificate, sslCertificateName string)
+
+	// PolicyViolation reports that mcrt was rejected by the cluster's
+	// domain policy before any backend resource was created for it.
+	PolicyViolation(mcrt api.ManagedCertificate, err error)
+
+	// TooManyCertificates reports that the backend refused to create a new
+	// certificate because the project's quota has been exhausted.
+	TooManyCertificates(mcrt api.ManagedCertificate, err error)
+}
+
+type event struct {
+	recorder record.EventRecorder
+}
+
+// New creates an Event backed by the given Kubernetes event recorder.
+func New(recorder record.EventRecorder) Event {
+	return &event{recorder: recorder}
+}
+
+func (e *event) BackendError(mcrt api.ManagedCertificate, err error) {
+	e.recorder.Event(&mcrt, "Warning", backendError, err.Error())
+}
+
+func (e *event) Create(mcrt api.ManagedCertificate, sslCertificateName string) {
+	e.recorder.Event(&mcrt, "Normal", create, sslCertificateName)
+}
+
+func (e *event) Delete(mcrt api.ManagedCertificate, sslCertificateName string) {
+	e.recorder.Event(&mcrt, "Normal", deleteReason, sslCertificateName)
+}
+
+func (e *event) PolicyViolation(mcrt api.ManagedCertificate, err error) {
+	e.recorder.Event(&mcrt, "Warning", policyViolation, err.Error())
+}
+
+func (e *event) TooManyCertificates(mcrt api.ManagedCertificate, err error) {
+	e.recorder.Event(&mcrt, "Warning", tooManyCertificates, err.Error())
+}
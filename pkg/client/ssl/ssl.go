@@ -0,0 +1,90 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ssl wraps the GCE Compute Beta SslCertificates API.
+package ssl
+
+import (
+	compute "google.golang.org/api/compute/v0.beta"
+)
+
+// Ssl abstracts operations on GCE SslCertificate resources.
+type Ssl interface {
+	// Create creates a new SslCertificate resource named name, covering domains.
+	Create(name string, domains []string) error
+
+	// CreateWithDescription is like Create, but also sets the resource's
+	// description field.
+	CreateWithDescription(name string, domains []string, description string) error
+
+	// Delete deletes the SslCertificate resource named name.
+	Delete(name string) error
+
+	// Exists reports whether an SslCertificate resource named name exists.
+	Exists(name string) (bool, error)
+
+	// Get fetches the SslCertificate resource named name.
+	Get(name string) (*compute.SslCertificate, error)
+}
+
+type ssl struct {
+	service *compute.SslCertificatesService
+	project string
+}
+
+// New creates an Ssl client for the given GCP project, backed by the
+// Compute Beta API.
+func New(service *compute.SslCertificatesService, project string) Ssl {
+	return &ssl{
+		service: service,
+		project: project,
+	}
+}
+
+func (s *ssl) Create(name string, domains []string) error {
+	return s.CreateWithDescription(name, domains, "")
+}
+
+func (s *ssl) CreateWithDescription(name string, domains []string, description string) error {
+	_, err := s.service.Insert(s.project, &compute.SslCertificate{
+		Managed: &compute.SslCertificateManagedSslCertificate{
+			Domains: domains,
+		},
+		Name:        name,
+		Type:        "MANAGED",
+		Description: description,
+	}).Do()
+	return err
+}
+
+func (s *ssl) Delete(name string) error {
+	_, err := s.service.Delete(s.project, name).Do()
+	return err
+}
+
+func (s *ssl) Exists(name string) (bool, error) {
+	if _, err := s.Get(name); err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *ssl) Get(name string) (*compute.SslCertificate, error) {
+	return s.service.Get(s.project, name).Do()
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sslcertificatemanager reconciles a ManagedCertificate against its
+// backing certificate resource in a pluggable certbackend/apiv1.Backend.
+package sslcertificatemanager
+
+import (
+	"fmt"
+	"time"
+
+	compute "google.golang.org/api/compute/v0.beta"
+
+	api "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/gke.googleapis.com/v1alpha1"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/certbackend/apiv1"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/client/event"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/policy"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/provenance"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/utils"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/version"
+)
+
+// SslCertificateManager manages a managed certificate resource backed by a
+// certbackend/apiv1.Backend, and reports associated events against the
+// owning ManagedCertificate.
+type SslCertificateManager struct {
+	event        event.Event
+	backend      apiv1.Backend
+	policy       *policy.Engine
+	project      string
+	nameTemplate *utils.NameTemplate
+}
+
+// New creates an SslCertificateManager that drives backend and reports
+// events through event. policyEngine may be nil, in which case every
+// ManagedCertificate is admitted. project is stamped into the provenance
+// of every certificate this manager creates. nameTemplate may be nil, in
+// which case GenerateName falls back to utils.DefaultNameTemplate.
+func New(event event.Event, backend apiv1.Backend, policyEngine *policy.Engine, project string, nameTemplate *utils.NameTemplate) SslCertificateManager {
+	return SslCertificateManager{
+		event:        event,
+		backend:      backend,
+		policy:       policyEngine,
+		project:      project,
+		nameTemplate: nameTemplate,
+	}
+}
+
+// GenerateName returns the SslCertificate resource name to use for mcrt,
+// computed from s's configured utils.NameTemplate. Callers generate a name
+// this way before their first call to Create for a given ManagedCertificate.
+func (s SslCertificateManager) GenerateName(mcrt api.ManagedCertificate) (string, error) {
+	if s.nameTemplate == nil {
+		return utils.DefaultNameTemplate.Generate(mcrt)
+	}
+	return s.nameTemplate.Generate(mcrt)
+}
+
+// Create creates a new certificate resource named sslCertificateName,
+// covering the domains in mcrt.Spec. If the cluster's domain policy
+// rejects one of those domains, mcrt.Status.CertificateStatus transitions
+// to the terminal api.CertificateStatusRejected, a PolicyViolation event is
+// reported, and the backend is never called. If the backend supports it,
+// the certificate's provenance (backend type, project, controller version
+// and owning ManagedCertificate) is recorded both in mcrt's provenance
+// annotation and in the backend resource's description field; see
+// pkg/provenance. mcrt is taken by pointer so that this annotation, and the
+// Rejected status, are visible to the caller, who is responsible for
+// persisting the updated ManagedCertificate.
+func (s SslCertificateManager) Create(sslCertificateName string, mcrt *api.ManagedCertificate) error {
+	if err := s.policy.Admit(mcrt.Spec.Domains); err != nil {
+		mcrt.Status.CertificateStatus = api.CertificateStatusRejected
+		s.event.PolicyViolation(*mcrt, err)
+		return err
+	}
+
+	err := s.create(sslCertificateName, mcrt)
+
+	if err == nil {
+		s.event.Create(*mcrt, sslCertificateName)
+		return nil
+	}
+
+	if s.backend.IsQuotaExceeded(err) {
+		s.event.TooManyCertificates(*mcrt, err)
+	} else {
+		s.event.BackendError(*mcrt, err)
+	}
+
+	return err
+}
+
+func (s SslCertificateManager) create(sslCertificateName string, mcrt *api.ManagedCertificate) error {
+	setter, ok := s.backend.(apiv1.DescriptionSetter)
+	if !ok {
+		return s.backend.Create(sslCertificateName, mcrt.Spec.Domains)
+	}
+
+	p := provenance.New(s.backend.Type(), s.project, version.Version, *mcrt, time.Now())
+	if err := provenance.Stamp(mcrt, p); err != nil {
+		return s.backend.Create(sslCertificateName, mcrt.Spec.Domains)
+	}
+
+	return setter.CreateWithDescription(sslCertificateName, mcrt.Spec.Domains, mcrt.Annotations[provenance.AnnotationKey])
+}
+
+// Delete deletes the certificate resource named sslCertificateName. mcrt is
+// nil if the ManagedCertificate that owned the certificate no longer
+// exists; in that case no Delete event is reported and no ownership check
+// is performed, since there is no ManagedCertificate left to check
+// ownership against.
+//
+// If mcrt is set and the existing certificate's provenance shows it was
+// created for a different ManagedCertificate, deletion is refused: this
+// closes the failure mode where a name collision between two
+// ManagedCertificates would otherwise silently delete the other one's
+// certificate.
+func (s SslCertificateManager) Delete(sslCertificateName string, mcrt *api.ManagedCertificate) error {
+	if mcrt != nil {
+		cert, err := s.backend.Get(sslCertificateName)
+
+		if err != nil && !isNotFound(err) {
+			s.event.BackendError(*mcrt, err)
+			return err
+		}
+
+		if err == nil {
+			owner, perr := provenance.Find(cert)
+
+			if perr != nil {
+				err := fmt.Errorf("sslcertificatemanager: refusing to delete %q: provenance present but undecodable: %v", sslCertificateName, perr)
+				s.event.BackendError(*mcrt, err)
+				return err
+			}
+
+			if owner != nil && !owner.Owns(*mcrt) {
+				err := fmt.Errorf("sslcertificatemanager: refusing to delete %q: owned by a different ManagedCertificate (namespace=%q name=%q uid=%q)",
+					sslCertificateName, owner.Namespace, owner.Name, owner.UID)
+				s.event.BackendError(*mcrt, err)
+				return err
+			}
+		}
+	}
+
+	err := s.backend.Delete(sslCertificateName)
+
+	if err != nil && isNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		if mcrt != nil {
+			s.event.BackendError(*mcrt, err)
+		}
+		return err
+	}
+
+	if mcrt != nil {
+		s.event.Delete(*mcrt, sslCertificateName)
+	}
+	return nil
+}
+
+// Exists reports whether the certificate resource named sslCertificateName
+// exists. mcrt is nil if the ManagedCertificate that owns the certificate no
+// longer exists; in that case no event is reported on error.
+func (s SslCertificateManager) Exists(sslCertificateName string, mcrt *api.ManagedCertificate) (bool, error) {
+	exists, err := s.backend.Exists(sslCertificateName)
+
+	if err != nil {
+		if mcrt != nil {
+			s.event.BackendError(*mcrt, err)
+		}
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// Get fetches the certificate resource named sslCertificateName. mcrt is nil
+// if the ManagedCertificate that owns the certificate no longer exists; in
+// that case no event is reported on error.
+func (s SslCertificateManager) Get(sslCertificateName string, mcrt *api.ManagedCertificate) (*compute.SslCertificate, error) {
+	cert, err := s.backend.Get(sslCertificateName)
+
+	if err != nil {
+		if mcrt != nil {
+			s.event.BackendError(*mcrt, err)
+		}
+		return nil, err
+	}
+
+	return cert, nil
+}
@@ -18,58 +18,81 @@ package sslcertificatemanager
 
 import (
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	api "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/gke.googleapis.com/v1alpha1"
 	compute "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/googleapi"
 
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/certbackend/apiv1"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/certbackend/apiv1/soft"
 	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/client/event"
-	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/client/ssl"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/policy"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/provenance"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/utils"
 )
 
-type fakeSsl struct {
+type fakeBackend struct {
 	err            error
 	exists         bool
 	sslCertificate *compute.SslCertificate
 }
 
-var _ ssl.Ssl = (*fakeSsl)(nil)
+var _ apiv1.Backend = (*fakeBackend)(nil)
 
-func (f fakeSsl) Create(name string, domains []string) error {
+func (f fakeBackend) Create(name string, domains []string) error {
 	return f.err
 }
 
-func (f fakeSsl) Delete(name string) error {
+func (f fakeBackend) Delete(name string) error {
 	return f.err
 }
 
-func (f fakeSsl) Exists(name string) (bool, error) {
+func (f fakeBackend) Exists(name string) (bool, error) {
 	return f.exists, f.err
 }
 
-func (f fakeSsl) Get(name string) (*compute.SslCertificate, error) {
+func (f fakeBackend) Get(name string) (*compute.SslCertificate, error) {
 	return f.sslCertificate, f.err
 }
 
-func withErr(err error) fakeSsl {
-	return fakeSsl{
+func (f fakeBackend) Type() apiv1.Type {
+	return apiv1.Soft
+}
+
+func (f fakeBackend) IsQuotaExceeded(err error) bool {
+	gerr, ok := err.(*googleapi.Error)
+	if !ok || gerr.Code != http.StatusForbidden {
+		return false
+	}
+	for _, item := range gerr.Errors {
+		if item.Reason == "quotaExceeded" {
+			return true
+		}
+	}
+	return false
+}
+
+func withErr(err error) fakeBackend {
+	return fakeBackend{
 		err:            err,
 		exists:         false,
 		sslCertificate: nil,
 	}
 }
 
-func withExists(err error, exists bool) fakeSsl {
-	return fakeSsl{
+func withExists(err error, exists bool) fakeBackend {
+	return fakeBackend{
 		err:            err,
 		exists:         exists,
 		sslCertificate: nil,
 	}
 }
 
-func withCert(err error, sslCertificate *compute.SslCertificate) fakeSsl {
-	return fakeSsl{
+func withCert(err error, sslCertificate *compute.SslCertificate) fakeBackend {
+	return fakeBackend{
 		err:            err,
 		exists:         false,
 		sslCertificate: sslCertificate,
@@ -77,10 +100,11 @@ func withCert(err error, sslCertificate *compute.SslCertificate) fakeSsl {
 }
 
 type fakeEvent struct {
-	backendErrorCnt int
-	createCnt       int
-	deleteCnt       int
-	tooManyCnt      int
+	backendErrorCnt    int
+	createCnt          int
+	deleteCnt          int
+	policyViolationCnt int
+	tooManyCnt         int
 }
 
 var _ event.Event = (*fakeEvent)(nil)
@@ -97,6 +121,10 @@ func (f *fakeEvent) Delete(mcrt api.ManagedCertificate, sslCertificateName strin
 	f.deleteCnt++
 }
 
+func (f *fakeEvent) PolicyViolation(mcrt api.ManagedCertificate, err error) {
+	f.policyViolationCnt++
+}
+
 func (f *fakeEvent) TooManyCertificates(mcrt api.ManagedCertificate, err error) {
 	f.tooManyCnt++
 }
@@ -118,7 +146,7 @@ var mcrt = &api.ManagedCertificate{}
 
 func TestCreate(t *testing.T) {
 	testCases := []struct {
-		sslIn                 ssl.Ssl
+		backendIn             apiv1.Backend
 		mcrtIn                api.ManagedCertificate
 		errOut                error
 		tooManyCertsGenerated bool
@@ -131,13 +159,13 @@ func TestCreate(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		event := &fakeEvent{0, 0, 0, 0}
+		event := &fakeEvent{0, 0, 0, 0, 0}
 		sut := SslCertificateManager{
-			event: event,
-			ssl:   testCase.sslIn,
+			event:   event,
+			backend: testCase.backendIn,
 		}
 
-		err := sut.Create("", testCase.mcrtIn)
+		err := sut.Create("", &testCase.mcrtIn)
 
 		if err != testCase.errOut {
 			t.Errorf("err %#v, want %#v", err, testCase.errOut)
@@ -157,9 +185,74 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreateRejectsPolicyViolation(t *testing.T) {
+	engine, err := policy.New(policy.Config{Allow: []string{"good.com"}})
+	if err != nil {
+		t.Fatalf("policy.New() failed: %v", err)
+	}
+
+	rejected := &api.ManagedCertificate{Spec: api.ManagedCertificateSpec{Domains: []string{"bad.com"}}}
+	event := &fakeEvent{0, 0, 0, 0, 0}
+	sut := SslCertificateManager{
+		event:   event,
+		backend: withErr(nil),
+		policy:  engine,
+	}
+
+	if err := sut.Create("", rejected); err == nil {
+		t.Error("Create() with a policy-rejected domain succeeded, want error")
+	}
+
+	if event.policyViolationCnt != 1 {
+		t.Errorf("PolicyViolation events generated: %d, want 1", event.policyViolationCnt)
+	}
+
+	if event.createCnt != 0 {
+		t.Errorf("Create events generated: %d, want 0 since the backend must not be called", event.createCnt)
+	}
+
+	if rejected.Status.CertificateStatus != api.CertificateStatusRejected {
+		t.Errorf("Status.CertificateStatus = %q, want %q", rejected.Status.CertificateStatus, api.CertificateStatusRejected)
+	}
+}
+
+func TestCreateStampsProvenance(t *testing.T) {
+	backend, err := soft.New(apiv1.Options{Project: "proj"})
+	if err != nil {
+		t.Fatalf("soft.New() failed: %v", err)
+	}
+
+	owned := &api.ManagedCertificate{}
+	owned.Namespace, owned.Name, owned.UID = "prod", "api", "uid-1"
+
+	event := &fakeEvent{0, 0, 0, 0, 0}
+	sut := SslCertificateManager{event: event, backend: backend, project: "proj"}
+
+	if err := sut.Create("cert-1", owned); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	if _, ok := owned.Annotations[provenance.AnnotationKey]; !ok {
+		t.Error("Create() did not set a provenance annotation on mcrt, want one")
+	}
+
+	cert, err := backend.Get("cert-1")
+	if err != nil {
+		t.Fatalf("backend.Get() failed: %v", err)
+	}
+
+	p, err := provenance.Find(cert)
+	if err != nil {
+		t.Fatalf("provenance.Find() failed: %v", err)
+	}
+	if p == nil || !p.Owns(*owned) {
+		t.Errorf("provenance.Find() = %+v, want provenance owned by %+v", p, owned)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	testCases := []struct {
-		sslIn                 ssl.Ssl
+		backendIn             apiv1.Backend
 		mcrtIn                *api.ManagedCertificate
 		errOut                error
 		backendErrorGenerated bool
@@ -174,10 +267,10 @@ func TestDelete(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		event := &fakeEvent{0, 0, 0, 0}
+		event := &fakeEvent{0, 0, 0, 0, 0}
 		sut := SslCertificateManager{
-			event: event,
-			ssl:   testCase.sslIn,
+			event:   event,
+			backend: testCase.backendIn,
 		}
 
 		err := sut.Delete("", testCase.mcrtIn)
@@ -196,9 +289,64 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestDeleteRefusesForeignOwnership(t *testing.T) {
+	owner := &api.ManagedCertificate{}
+	owner.Namespace, owner.Name, owner.UID = "other-ns", "other-mcrt", "other-uid"
+
+	p := provenance.New(apiv1.Soft, "my-project", "v1", *owner, time.Unix(0, 0))
+	description, err := p.Encode()
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+
+	event := &fakeEvent{0, 0, 0, 0, 0}
+	sut := SslCertificateManager{
+		event:   event,
+		backend: withCert(nil, &compute.SslCertificate{Description: description}),
+	}
+
+	caller := &api.ManagedCertificate{}
+	caller.Namespace, caller.Name, caller.UID = "this-ns", "this-mcrt", "this-uid"
+
+	if err := sut.Delete("shared-name", caller); err == nil {
+		t.Error("Delete() of a certificate owned by a different ManagedCertificate succeeded, want error")
+	}
+
+	if event.backendErrorCnt != 1 {
+		t.Errorf("BackendError events generated: %d, want 1", event.backendErrorCnt)
+	}
+
+	if event.deleteCnt != 0 {
+		t.Errorf("Delete events generated: %d, want 0 since the certificate must not be deleted", event.deleteCnt)
+	}
+}
+
+func TestDeleteRefusesUndecodableProvenance(t *testing.T) {
+	event := &fakeEvent{0, 0, 0, 0, 0}
+	sut := SslCertificateManager{
+		event:   event,
+		backend: withCert(nil, &compute.SslCertificate{Description: "not valid base64 DER"}),
+	}
+
+	caller := &api.ManagedCertificate{}
+	caller.Namespace, caller.Name, caller.UID = "this-ns", "this-mcrt", "this-uid"
+
+	if err := sut.Delete("shared-name", caller); err == nil {
+		t.Error("Delete() of a certificate with undecodable provenance succeeded, want error")
+	}
+
+	if event.backendErrorCnt != 1 {
+		t.Errorf("BackendError events generated: %d, want 1", event.backendErrorCnt)
+	}
+
+	if event.deleteCnt != 0 {
+		t.Errorf("Delete events generated: %d, want 0 since a certificate with unreadable provenance must fail safe and not be deleted", event.deleteCnt)
+	}
+}
+
 func TestExists(t *testing.T) {
 	testCases := []struct {
-		sslIn          ssl.Ssl
+		backendIn      apiv1.Backend
 		mcrtIn         *api.ManagedCertificate
 		existsOut      bool
 		errOut         error
@@ -215,10 +363,10 @@ func TestExists(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		event := &fakeEvent{0, 0, 0, 0}
+		event := &fakeEvent{0, 0, 0, 0, 0}
 		sut := SslCertificateManager{
-			event: event,
-			ssl:   testCase.sslIn,
+			event:   event,
+			backend: testCase.backendIn,
 		}
 
 		exists, err := sut.Exists("", testCase.mcrtIn)
@@ -237,7 +385,7 @@ func TestExists(t *testing.T) {
 
 func TestGet(t *testing.T) {
 	testCases := []struct {
-		sslIn          ssl.Ssl
+		backendIn      apiv1.Backend
 		mcrtIn         *api.ManagedCertificate
 		certOut        *compute.SslCertificate
 		errOut         error
@@ -254,10 +402,10 @@ func TestGet(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		event := &fakeEvent{0, 0, 0, 0}
+		event := &fakeEvent{0, 0, 0, 0, 0}
 		sut := SslCertificateManager{
-			event: event,
-			ssl:   testCase.sslIn,
+			event:   event,
+			backend: testCase.backendIn,
 		}
 
 		sslCert, err := sut.Get("", testCase.mcrtIn)
@@ -273,3 +421,37 @@ func TestGet(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateNameFallsBackToDefaultTemplate(t *testing.T) {
+	sut := SslCertificateManager{}
+
+	name, err := sut.GenerateName(*mcrt)
+	if err != nil {
+		t.Fatalf("GenerateName() failed: %v", err)
+	}
+	if len(name) <= 0 || len(name) >= 64 {
+		t.Errorf("GenerateName() = %q, want a name between 1 and 63 characters", name)
+	}
+}
+
+func TestGenerateNameUsesConfiguredTemplate(t *testing.T) {
+	tpl, err := utils.Parse("mcrt-{{ .Namespace }}-{{ .Name }}-{{ .UID }}")
+	if err != nil {
+		t.Fatalf("utils.Parse() failed: %v", err)
+	}
+
+	owned := &api.ManagedCertificate{}
+	owned.Namespace, owned.Name, owned.UID = "prod", "api", "uid-1"
+
+	sut := SslCertificateManager{nameTemplate: tpl}
+
+	name, err := sut.GenerateName(*owned)
+	if err != nil {
+		t.Fatalf("GenerateName() failed: %v", err)
+	}
+
+	want := "mcrt-prod-api-uid-1"
+	if name != want {
+		t.Errorf("GenerateName() = %q, want %q", name, want)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"io/ioutil"
+
+	"sigs.k8s.io/yaml"
+
+	api "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/gke.googleapis.com/v1alpha1"
+)
+
+// LoadFromFile reads and parses a Config from the YAML file at path. This
+// module defines no command-line flag that supplies path.
+func LoadFromFile(path string) (Config, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// FromDomainPolicy converts a cluster-scoped DomainPolicy resource into a
+// Config, so the same rules can be authored as a CRD and updated live
+// instead of requiring a controller restart.
+func FromDomainPolicy(domainPolicy *api.DomainPolicy) Config {
+	return Config{
+		Allow: domainPolicy.Spec.Allow,
+		Deny:  domainPolicy.Spec.Deny,
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	api "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/gke.googleapis.com/v1alpha1"
+)
+
+func TestLoadFromFile(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		contents string
+		want     Config
+		wantErr  bool
+	}{
+		{
+			desc:     "well-formed YAML",
+			contents: "allow:\n- example.com\n- \"*.example.com\"\ndeny:\n- bad.example.com\n",
+			want:     Config{Allow: []string{"example.com", "*.example.com"}, Deny: []string{"bad.example.com"}},
+		},
+		{
+			desc:     "empty file admits everything",
+			contents: "",
+			want:     Config{},
+		},
+		{
+			desc:     "malformed YAML",
+			contents: "allow: [this is not valid",
+			wantErr:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "policy.yaml")
+			if err := os.WriteFile(path, []byte(testCase.contents), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			got, err := LoadFromFile(path)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("LoadFromFile() error = %v, wantErr %t", err, testCase.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("LoadFromFile() = %#v, want %#v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestLoadFromFileMissing(t *testing.T) {
+	if _, err := LoadFromFile(filepath.Join(t.TempDir(), "does-not-exist.yaml")); err == nil {
+		t.Error("LoadFromFile() for a missing file succeeded, want error")
+	}
+}
+
+func TestFromDomainPolicy(t *testing.T) {
+	domainPolicy := &api.DomainPolicy{
+		Spec: api.DomainPolicySpec{
+			Allow: []string{"example.com"},
+			Deny:  []string{"bad.example.com"},
+		},
+	}
+
+	want := Config{Allow: []string{"example.com"}, Deny: []string{"bad.example.com"}}
+	if got := FromDomainPolicy(domainPolicy); !reflect.DeepEqual(got, want) {
+		t.Errorf("FromDomainPolicy() = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy restricts which domains may appear in a ManagedCertificate,
+// via a set of allow and deny rules over exact DNS names, single-label
+// wildcards, and DNS suffixes. A domain is admitted only if it matches at
+// least one allow rule and no deny rule; deny always wins. If no allow
+// rules are configured, any domain that matches no deny rule is admitted.
+package policy
+
+import (
+	"fmt"
+)
+
+// Config is the raw, uncompiled allow/deny rule configuration, as loaded
+// from a YAML file or a DomainPolicy resource.
+type Config struct {
+	Allow []string `yaml:"allow" json:"allow,omitempty"`
+	Deny  []string `yaml:"deny" json:"deny,omitempty"`
+}
+
+// Engine is a compiled, ready-to-evaluate Config.
+type Engine struct {
+	allow []rule
+	deny  []rule
+}
+
+// New compiles cfg into an Engine, validating every pattern up front so that
+// a malformed configuration is rejected at load time rather than at
+// admission time.
+func New(cfg Config) (*Engine, error) {
+	allow, err := compileRules(cfg.Allow)
+	if err != nil {
+		return nil, fmt.Errorf("policy: invalid allow rules: %v", err)
+	}
+
+	deny, err := compileRules(cfg.Deny)
+	if err != nil {
+		return nil, fmt.Errorf("policy: invalid deny rules: %v", err)
+	}
+
+	return &Engine{allow: allow, deny: deny}, nil
+}
+
+func compileRules(patterns []string) ([]rule, error) {
+	var rules []rule
+	for _, pattern := range patterns {
+		r, err := parseRule(pattern)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// ViolationError reports that domain failed admission, and why.
+type ViolationError struct {
+	Domain string
+	Reason string
+}
+
+func (e *ViolationError) Error() string {
+	return fmt.Sprintf("domain %q %s", e.Domain, e.Reason)
+}
+
+// Admit reports whether every domain in domains is allowed by e. An empty
+// Engine (no allow and no deny rules) admits everything, preserving
+// today's behavior for clusters that do not configure a policy.
+func (e *Engine) Admit(domains []string) error {
+	if e == nil || (len(e.allow) == 0 && len(e.deny) == 0) {
+		return nil
+	}
+
+	for _, domain := range domains {
+		name, err := normalize(domain)
+		if err != nil {
+			return &ViolationError{Domain: domain, Reason: fmt.Sprintf("is not a valid DNS name: %v", err)}
+		}
+
+		if matchesAny(e.deny, name) {
+			return &ViolationError{Domain: domain, Reason: "is explicitly denied by policy"}
+		}
+
+		if len(e.allow) > 0 && !matchesAny(e.allow, name) {
+			return &ViolationError{Domain: domain, Reason: "does not match any allow rule"}
+		}
+	}
+
+	return nil
+}
+
+func matchesAny(rules []rule, domain string) bool {
+	for _, r := range rules {
+		if r.matches(domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+)
+
+func TestAdmit(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		cfg     Config
+		domains []string
+		wantErr bool
+	}{
+		{"no policy admits everything", Config{}, []string{"example.com"}, false},
+		{"exact allow matches", Config{Allow: []string{"example.com"}}, []string{"example.com"}, false},
+		{"exact allow does not match other domain", Config{Allow: []string{"example.com"}}, []string{"other.com"}, true},
+		{"wildcard allow matches single label", Config{Allow: []string{"*.example.com"}}, []string{"foo.example.com"}, false},
+		{"wildcard allow does not match base domain", Config{Allow: []string{"*.example.com"}}, []string{"example.com"}, true},
+		{"wildcard allow does not match two labels", Config{Allow: []string{"*.example.com"}}, []string{"a.b.example.com"}, true},
+		{"suffix allow matches any depth", Config{Allow: []string{".example.com"}}, []string{"a.b.example.com"}, false},
+		{"deny wins over allow", Config{Allow: []string{"*.example.com"}, Deny: []string{"bad.example.com"}}, []string{"bad.example.com"}, true},
+		{"case normalization", Config{Allow: []string{"example.com"}}, []string{"EXAMPLE.com"}, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			engine, err := New(testCase.cfg)
+			if err != nil {
+				t.Fatalf("New(%#v) failed: %v", testCase.cfg, err)
+			}
+
+			err = engine.Admit(testCase.domains)
+			if (err != nil) != testCase.wantErr {
+				t.Errorf("Admit(%v) = %v, wantErr %t", testCase.domains, err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRuleRejectsEmbeddedWildcard(t *testing.T) {
+	if _, err := New(Config{Allow: []string{"foo.*.example.com"}}); err == nil {
+		t.Error("New() with embedded wildcard pattern succeeded, want error")
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// ruleKind distinguishes how a rule's pattern is matched against a domain.
+type ruleKind int
+
+const (
+	ruleExact ruleKind = iota
+	ruleWildcard
+	ruleSuffix
+)
+
+// rule is a single compiled allow or deny pattern.
+type rule struct {
+	kind    ruleKind
+	pattern string // normalized, without the leading "*." or "." marker
+}
+
+// parseRule normalizes and classifies a raw pattern string, rejecting
+// patterns with embedded wildcards (a wildcard label may only appear as the
+// first label).
+func parseRule(raw string) (rule, error) {
+	switch {
+	case strings.HasPrefix(raw, "*."):
+		rest := raw[2:]
+		if strings.Contains(rest, "*") {
+			return rule{}, fmt.Errorf("policy: embedded wildcard not allowed in %q", raw)
+		}
+		name, err := normalize(rest)
+		if err != nil {
+			return rule{}, fmt.Errorf("policy: invalid pattern %q: %v", raw, err)
+		}
+		return rule{kind: ruleWildcard, pattern: name}, nil
+
+	case strings.HasPrefix(raw, "."):
+		rest := raw[1:]
+		if strings.Contains(rest, "*") {
+			return rule{}, fmt.Errorf("policy: embedded wildcard not allowed in %q", raw)
+		}
+		name, err := normalize(rest)
+		if err != nil {
+			return rule{}, fmt.Errorf("policy: invalid pattern %q: %v", raw, err)
+		}
+		return rule{kind: ruleSuffix, pattern: name}, nil
+
+	case strings.Contains(raw, "*"):
+		return rule{}, fmt.Errorf("policy: embedded wildcard not allowed in %q", raw)
+
+	default:
+		name, err := normalize(raw)
+		if err != nil {
+			return rule{}, fmt.Errorf("policy: invalid pattern %q: %v", raw, err)
+		}
+		return rule{kind: ruleExact, pattern: name}, nil
+	}
+}
+
+// matches reports whether domain (already normalized) satisfies r.
+func (r rule) matches(domain string) bool {
+	switch r.kind {
+	case ruleExact:
+		return domain == r.pattern
+	case ruleWildcard:
+		return matchesSingleLabelWildcard(domain, r.pattern)
+	case ruleSuffix:
+		return domain == r.pattern || strings.HasSuffix(domain, "."+r.pattern)
+	default:
+		return false
+	}
+}
+
+// matchesSingleLabelWildcard reports whether domain has exactly one label
+// more than suffix, i.e. "*.example.com" matches "foo.example.com" but not
+// "example.com" or "a.b.example.com".
+func matchesSingleLabelWildcard(domain, suffix string) bool {
+	if !strings.HasSuffix(domain, "."+suffix) {
+		return false
+	}
+	label := strings.TrimSuffix(domain, "."+suffix)
+	return len(label) > 0 && !strings.Contains(label, ".")
+}
+
+// normalize converts name to its canonical, lower-case, punycode-encoded
+// ASCII form so that e.g. "ünicode.example.com" and "xn--nicode-2ya.example.com"
+// compare equal.
+func normalize(name string) (string, error) {
+	return idna.Lookup.ToASCII(strings.ToLower(name))
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package provenance stamps every ManagedCertificate-owned certificate
+// resource with structured metadata identifying the backend, project and
+// controller build that created it, and the ManagedCertificate that owns
+// it. It follows the same shape as smallstep's
+// CreateCertificateAuthorityExtension: a small struct is DER-encoded and
+// carried as opaque bytes, here as a base64 annotation on the
+// ManagedCertificate and a description field on the backend resource,
+// since the GCE Compute SSL API does not support custom X.509 extensions
+// on managed certificates.
+package provenance
+
+import (
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	compute "google.golang.org/api/compute/v0.beta"
+
+	api "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/gke.googleapis.com/v1alpha1"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/certbackend/apiv1"
+)
+
+// AnnotationKey is the ManagedCertificate annotation that carries a
+// base64-encoded, DER-marshaled Provenance.
+const AnnotationKey = "managedcertificates.gke.googleapis.com/provenance"
+
+// KeyValue is a single caller-supplied extra metadata entry.
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+// Provenance records who created a certificate resource and for what
+// ManagedCertificate, so that a later Delete can tell whether it is safe to
+// remove a resource found only by name.
+type Provenance struct {
+	BackendType       string
+	Project           string
+	ControllerVersion string
+	Namespace         string
+	Name              string
+	UID               string
+	CreatedAt         time.Time
+	Extra             []KeyValue
+}
+
+// New builds a Provenance identifying backendType and project as the
+// creator of a certificate resource owned by mcrt, stamped with the
+// running controllerVersion and createdAt. extra carries optional
+// caller-supplied key/value pairs.
+func New(backendType apiv1.Type, project, controllerVersion string, mcrt api.ManagedCertificate, createdAt time.Time, extra ...KeyValue) *Provenance {
+	return &Provenance{
+		BackendType:       string(backendType),
+		Project:           project,
+		ControllerVersion: controllerVersion,
+		Namespace:         mcrt.Namespace,
+		Name:              mcrt.Name,
+		UID:               string(mcrt.UID),
+		CreatedAt:         createdAt,
+		Extra:             extra,
+	}
+}
+
+// Marshal DER-encodes p.
+func (p *Provenance) Marshal() ([]byte, error) {
+	return asn1.Marshal(*p)
+}
+
+// Encode DER-encodes p and base64-encodes the result, for embedding in an
+// annotation value or a resource description field.
+func (p *Provenance) Encode() (string, error) {
+	der, err := p.Marshal()
+	if err != nil {
+		return "", fmt.Errorf("provenance: failed to marshal: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(der), nil
+}
+
+// Owns reports whether p records mcrt as the owning ManagedCertificate,
+// identified by namespace, name and UID.
+func (p *Provenance) Owns(mcrt api.ManagedCertificate) bool {
+	return p.Namespace == mcrt.Namespace && p.Name == mcrt.Name && p.UID == string(mcrt.UID)
+}
+
+// Decode parses a base64-encoded, DER-marshaled Provenance, as produced by
+// Encode.
+func Decode(encoded string) (*Provenance, error) {
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("provenance: failed to base64-decode: %v", err)
+	}
+
+	var p Provenance
+	if _, err := asn1.Unmarshal(der, &p); err != nil {
+		return nil, fmt.Errorf("provenance: failed to unmarshal: %v", err)
+	}
+	return &p, nil
+}
+
+// Stamp sets mcrt's provenance annotation to p's encoded form. The caller
+// is responsible for persisting the updated ManagedCertificate.
+func Stamp(mcrt *api.ManagedCertificate, p *Provenance) error {
+	encoded, err := p.Encode()
+	if err != nil {
+		return err
+	}
+
+	if mcrt.Annotations == nil {
+		mcrt.Annotations = make(map[string]string)
+	}
+	mcrt.Annotations[AnnotationKey] = encoded
+	return nil
+}
+
+// Find parses the Provenance embedded in cert's description field, if any.
+// It returns nil, nil if cert carries no provenance, so callers can
+// distinguish "no provenance" (e.g. a certificate predating this feature)
+// from a malformed one.
+func Find(cert *compute.SslCertificate) (*Provenance, error) {
+	if cert == nil || cert.Description == "" {
+		return nil, nil
+	}
+	return Decode(cert.Description)
+}
+
+// FindFromMcrt parses the Provenance stored in mcrt's provenance
+// annotation, if any. It returns nil, nil if mcrt carries no annotation.
+func FindFromMcrt(mcrt *api.ManagedCertificate) (*Provenance, error) {
+	if mcrt == nil {
+		return nil, nil
+	}
+
+	encoded, ok := mcrt.Annotations[AnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+
+	return Decode(encoded)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"testing"
+	"time"
+
+	compute "google.golang.org/api/compute/v0.beta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/gke.googleapis.com/v1alpha1"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/certbackend/apiv1"
+)
+
+func testMcrt() api.ManagedCertificate {
+	return api.ManagedCertificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "mcrt",
+			UID:       "uid-1",
+		},
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	mcrt := testMcrt()
+	want := New(apiv1.ComputeSSL, "my-project", "v1.2.3", mcrt, time.Unix(0, 0).UTC(), KeyValue{Key: "k", Value: "v"})
+
+	encoded, err := want.Encode()
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+
+	got, err := Decode(encoded)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+
+	if got.BackendType != want.BackendType || got.Project != want.Project || got.ControllerVersion != want.ControllerVersion ||
+		got.Namespace != want.Namespace || got.Name != want.Name || got.UID != want.UID {
+		t.Errorf("Decode() = %#v, want %#v", got, want)
+	}
+
+	if !got.Owns(mcrt) {
+		t.Errorf("Owns(%#v) = false, want true", mcrt)
+	}
+}
+
+func TestStampAndFindFromMcrt(t *testing.T) {
+	mcrt := testMcrt()
+	p := New(apiv1.Soft, "my-project", "v1.2.3", mcrt, time.Unix(0, 0).UTC())
+
+	if err := Stamp(&mcrt, p); err != nil {
+		t.Fatalf("Stamp() failed: %v", err)
+	}
+
+	got, err := FindFromMcrt(&mcrt)
+	if err != nil {
+		t.Fatalf("FindFromMcrt() failed: %v", err)
+	}
+
+	if !got.Owns(mcrt) {
+		t.Errorf("Owns(%#v) = false, want true", mcrt)
+	}
+}
+
+func TestFindFromMcrtWithoutAnnotation(t *testing.T) {
+	mcrt := testMcrt()
+
+	got, err := FindFromMcrt(&mcrt)
+	if err != nil {
+		t.Fatalf("FindFromMcrt() failed: %v", err)
+	}
+	if got != nil {
+		t.Errorf("FindFromMcrt() = %#v, want nil", got)
+	}
+}
+
+func TestFindWithoutDescription(t *testing.T) {
+	got, err := Find(&compute.SslCertificate{})
+	if err != nil {
+		t.Fatalf("Find() failed: %v", err)
+	}
+	if got != nil {
+		t.Errorf("Find() = %#v, want nil", got)
+	}
+}
+
+func TestFind(t *testing.T) {
+	mcrt := testMcrt()
+	p := New(apiv1.ComputeSSL, "my-project", "v1.2.3", mcrt, time.Unix(0, 0).UTC())
+	encoded, err := p.Encode()
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+
+	got, err := Find(&compute.SslCertificate{Description: encoded})
+	if err != nil {
+		t.Fatalf("Find() failed: %v", err)
+	}
+
+	if !got.Owns(mcrt) {
+		t.Errorf("Owns(%#v) = false, want true", mcrt)
+	}
+}
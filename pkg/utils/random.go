@@ -0,0 +1,48 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package utils provides small helpers shared across the controller,
+// starting with generating names for the SslCertificate resources backing
+// a ManagedCertificate.
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+const randomNamePrefix = "mcrt-"
+
+// RandomName generates an opaque, practically unique name for a backend
+// certificate resource, shorter than GCE's 63 character resource name
+// limit.
+func RandomName() (string, error) {
+	suffix, err := shortHash(16)
+	if err != nil {
+		return "", fmt.Errorf("utils: failed to generate random name: %v", err)
+	}
+	return randomNamePrefix + suffix, nil
+}
+
+// shortHash returns a random hex string n characters long.
+func shortHash(n int) (string, error) {
+	b := make([]byte, (n+1)/2)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b)[:n], nil
+}
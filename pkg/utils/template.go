@@ -0,0 +1,139 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	api "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/gke.googleapis.com/v1alpha1"
+)
+
+// gceNameLimit is the maximum length of a GCE resource name.
+const gceNameLimit = 63
+
+// DefaultTemplate is the empty template string, which Parse maps to
+// DefaultNameTemplate. It preserves today's fully random, mcrt-independent
+// naming behavior. This module defines no flag that supplies a template.
+const DefaultTemplate = ""
+
+// TemplateData is the set of variables available to a NameTemplate.
+type TemplateData struct {
+	Namespace   string
+	Name        string
+	UID         string
+	DomainsHash string
+	Timestamp   string
+}
+
+// NameTemplate generates SslCertificate resource names from a ManagedCertificate,
+// using an operator-supplied text/template string, so that a certificate found
+// in the GCP console can be traced back to the ManagedCertificate that owns it
+// without cross-referencing annotations.
+type NameTemplate struct {
+	raw string
+	tpl *template.Template
+}
+
+// DefaultNameTemplate is a NameTemplate that reproduces today's behavior:
+// an opaque random name, unrelated to the owning ManagedCertificate.
+var DefaultNameTemplate = &NameTemplate{raw: DefaultTemplate}
+
+// Parse compiles raw into a NameTemplate. raw must be empty (selecting the
+// default, fully random naming) or a valid text/template string that
+// includes at least one of {{.UID}}, {{.DomainsHash}} or shortHash, so
+// that names cannot collide across namespaces that otherwise share the
+// same template output.
+func Parse(raw string) (*NameTemplate, error) {
+	if raw == DefaultTemplate {
+		return DefaultNameTemplate, nil
+	}
+
+	if !strings.Contains(raw, ".UID") && !strings.Contains(raw, ".DomainsHash") && !strings.Contains(raw, "shortHash") {
+		return nil, fmt.Errorf("utils: name template %q must reference at least one of {{.UID}}, {{.DomainsHash}} or shortHash to avoid name collisions across namespaces", raw)
+	}
+
+	tpl, err := template.New("name").Funcs(template.FuncMap{"shortHash": shortHash}).Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("utils: failed to parse name template %q: %v", raw, err)
+	}
+
+	return &NameTemplate{raw: raw, tpl: tpl}, nil
+}
+
+// Generate produces an SslCertificate resource name for mcrt. If t is the
+// default template, RandomName is used unchanged; otherwise the compiled
+// text/template is executed against mcrt and the result is truncated to
+// fit the GCE resource name limit by hashing any overflow, rather than
+// blindly cutting it off, so two names that only differ past the limit
+// don't collide.
+func (t *NameTemplate) Generate(mcrt api.ManagedCertificate) (string, error) {
+	if t == nil || t.raw == DefaultTemplate {
+		return RandomName()
+	}
+
+	data := TemplateData{
+		Namespace:   mcrt.Namespace,
+		Name:        mcrt.Name,
+		UID:         string(mcrt.UID),
+		DomainsHash: domainsHash(mcrt.Spec.Domains),
+		Timestamp:   strconv.FormatInt(time.Now().Unix(), 10),
+	}
+
+	var buf bytes.Buffer
+	if err := t.tpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("utils: failed to execute name template %q: %v", t.raw, err)
+	}
+
+	return enforceNameLimit(buf.String()), nil
+}
+
+// domainsHash returns a short, stable hash of domains, independent of
+// their order, for use as a {{.DomainsHash}} template variable.
+func domainsHash(domains []string) string {
+	sorted := append([]string(nil), domains...)
+	sort.Strings(sorted)
+
+	sum := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// enforceNameLimit truncates name to gceNameLimit characters if needed, by
+// replacing the overflowing suffix with a hash of the full name so that
+// two names sharing only their first gceNameLimit-9 characters don't
+// collide once truncated.
+func enforceNameLimit(name string) string {
+	if len(name) <= gceNameLimit {
+		return name
+	}
+
+	sum := sha256.Sum256([]byte(name))
+	suffix := hex.EncodeToString(sum[:])[:8]
+
+	prefixLen := gceNameLimit - len(suffix) - 1
+	if prefixLen < 0 {
+		prefixLen = 0
+	}
+	return name[:prefixLen] + "-" + suffix
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	api "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/gke.googleapis.com/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testMcrt() api.ManagedCertificate {
+	return api.ManagedCertificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "prod",
+			Name:      "api",
+			UID:       "abc-123",
+		},
+		Spec: api.ManagedCertificateSpec{
+			Domains: []string{"api.example.com"},
+		},
+	}
+}
+
+func TestParseRejectsTemplateWithoutCollisionSafeVariable(t *testing.T) {
+	if _, err := Parse("mcrt-{{ .Namespace }}-{{ .Name }}"); err == nil {
+		t.Error("Parse() with no UID, DomainsHash or shortHash succeeded, want error")
+	}
+}
+
+func TestParseAcceptsDefaultTemplate(t *testing.T) {
+	tpl, err := Parse(DefaultTemplate)
+	if err != nil {
+		t.Fatalf("Parse(DefaultTemplate) failed: %v", err)
+	}
+	if tpl != DefaultNameTemplate {
+		t.Errorf("Parse(DefaultTemplate) = %v, want DefaultNameTemplate", tpl)
+	}
+}
+
+func TestGenerateWithDefaultTemplateIsRandom(t *testing.T) {
+	mcrt := testMcrt()
+
+	first, err := DefaultNameTemplate.Generate(mcrt)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	second, err := DefaultNameTemplate.Generate(mcrt)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	if first == second {
+		t.Errorf("Generate() called twice for the same mcrt returned the same name %q, want different", first)
+	}
+}
+
+func TestGenerateWithCustomTemplate(t *testing.T) {
+	tpl, err := Parse("mcrt-{{ .Namespace }}-{{ .Name }}-{{ .UID }}")
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	mcrt := testMcrt()
+	got, err := tpl.Generate(mcrt)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	want := "mcrt-prod-api-abc-123"
+	if got != want {
+		t.Errorf("Generate() = %q, want %q", got, want)
+	}
+}
+
+func TestGenerateTraceableToNamespaceAndName(t *testing.T) {
+	tpl, err := Parse("mcrt-{{ .Namespace }}-{{ .Name }}-{{ .DomainsHash }}")
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	mcrt := testMcrt()
+	got, err := tpl.Generate(mcrt)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	if !strings.HasPrefix(got, "mcrt-prod-api-") {
+		t.Errorf("Generate() = %q, want prefix %q", got, "mcrt-prod-api-")
+	}
+}
+
+func TestGenerateEnforcesGceNameLimitByHashingOverflow(t *testing.T) {
+	tpl, err := Parse("mcrt-" + strings.Repeat("x", 80) + "-{{ .UID }}")
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	got, err := tpl.Generate(testMcrt())
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	if len(got) > 63 {
+		t.Errorf("Generate() returned a name %d characters long, want <= 63", len(got))
+	}
+}
+
+func TestDomainsHashIsOrderIndependent(t *testing.T) {
+	if domainsHash([]string{"a.com", "b.com"}) != domainsHash([]string{"b.com", "a.com"}) {
+		t.Error("domainsHash() is order-dependent, want order-independent")
+	}
+}
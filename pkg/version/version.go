@@ -0,0 +1,24 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package version holds the controller's build version, overridden at
+// build time via -ldflags "-X .../pkg/version.Version=...".
+package version
+
+// Version identifies the running build of the controller. It is stamped
+// into pkg/provenance records so that a certificate can be traced back to
+// the controller build that created it.
+var Version = "dev"